@@ -0,0 +1,440 @@
+package goparquet
+
+import (
+	"fmt"
+
+	"github.com/fraugster/parquet-go/parquet"
+)
+
+// SchemaBuilder builds a *SchemaDefinition programmatically, without going
+// through a text round-trip via ParseSchemaDefinition. It's intended for
+// callers that generate their schema from code and would otherwise have to
+// resort to fragile string templating.
+//
+// A SchemaBuilder is not safe for concurrent use.
+type SchemaBuilder struct {
+	root    *groupBuilder
+	group   *groupBuilder
+	nextFID int32
+	err     error
+}
+
+// groupBuilder accumulates the children of a single group (or the root
+// message) while it is open.
+type groupBuilder struct {
+	name     string
+	parent   *groupBuilder
+	elem     *parquet.SchemaElement
+	children []*column
+	names    map[string]bool
+}
+
+// ElementOption customizes a single element produced by the SchemaBuilder,
+// e.g. WithLogicalType or WithConvertedType.
+type ElementOption func(*parquet.SchemaElement)
+
+// WithLogicalType sets the LogicalType of the element being built.
+func WithLogicalType(lt *parquet.LogicalType) ElementOption {
+	return func(elem *parquet.SchemaElement) {
+		elem.LogicalType = lt
+	}
+}
+
+// WithConvertedType sets the ConvertedType of the element being built.
+func WithConvertedType(ct parquet.ConvertedType) ElementOption {
+	return func(elem *parquet.SchemaElement) {
+		elem.ConvertedType = &ct
+	}
+}
+
+// WithTypeLength sets the TypeLength of the element being built; required
+// for FIXED_LEN_BYTE_ARRAY fields.
+func WithTypeLength(length int32) ElementOption {
+	return func(elem *parquet.SchemaElement) {
+		elem.TypeLength = &length
+	}
+}
+
+// WithPrecisionScale sets the Precision and Scale of a DECIMAL element.
+func WithPrecisionScale(precision, scale int32) ElementOption {
+	return func(elem *parquet.SchemaElement) {
+		elem.Precision = &precision
+		elem.Scale = &scale
+	}
+}
+
+// Repetition is the repetition type of a field or group added to a
+// SchemaBuilder.
+type Repetition int
+
+// Supported repetition types.
+const (
+	Required Repetition = iota
+	Optional
+	Repeated
+)
+
+func (r Repetition) fieldRepetitionType() parquet.FieldRepetitionType {
+	switch r {
+	case Optional:
+		return parquet.FieldRepetitionType_OPTIONAL
+	case Repeated:
+		return parquet.FieldRepetitionType_REPEATED
+	default:
+		return parquet.FieldRepetitionType_REQUIRED
+	}
+}
+
+// StringLogical returns the LogicalType for a UTF-8 string, for use with
+// WithLogicalType.
+func StringLogical() *parquet.LogicalType {
+	return &parquet.LogicalType{STRING: &parquet.StringType{}}
+}
+
+// DecimalLogical returns the LogicalType for a DECIMAL(precision, scale), for
+// use with WithLogicalType.
+func DecimalLogical(precision, scale int32) *parquet.LogicalType {
+	return &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: precision, Scale: scale}}
+}
+
+// NewSchemaBuilder creates a SchemaBuilder for a message with the given root
+// name.
+func NewSchemaBuilder(name string) *SchemaBuilder {
+	root := &groupBuilder{
+		name:  name,
+		elem:  &parquet.SchemaElement{Name: name},
+		names: make(map[string]bool),
+	}
+	return &SchemaBuilder{root: root, group: root, nextFID: 1}
+}
+
+func (b *SchemaBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// addField registers name in the currently open group, failing the build if
+// name has already been used in that group. Every field added through
+// addField (scalars, groups, lists and maps alike) receives the next
+// automatically assigned FieldID, unless one was already set by the caller.
+func (b *SchemaBuilder) addField(name string, col *column) {
+	if b.err != nil {
+		return
+	}
+	if b.group.names[name] {
+		b.fail(fmt.Errorf("goparquet: duplicate field name %q in group %q", name, b.group.name))
+		return
+	}
+	if err := validateLogicalGroupShape(col.element, col.children); err != nil {
+		b.fail(fmt.Errorf("goparquet: field %q: %w", name, err))
+		return
+	}
+	b.group.names[name] = true
+	if col.element.FieldID == nil {
+		fid := b.nextFID
+		col.element.FieldID = &fid
+		b.nextFID++
+	}
+	b.group.children = append(b.group.children, col)
+}
+
+func (b *SchemaBuilder) addScalar(name string, typ parquet.Type, rep Repetition, opts []ElementOption) *SchemaBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	elem := &parquet.SchemaElement{
+		Name:           name,
+		Type:           typePtr(typ),
+		RepetitionType: repetitionPtr(rep.fieldRepetitionType()),
+	}
+	for _, opt := range opts {
+		opt(elem)
+	}
+	if typ == parquet.Type_FIXED_LEN_BYTE_ARRAY && elem.TypeLength == nil {
+		b.fail(fmt.Errorf("goparquet: field %q: FIXED_LEN_BYTE_ARRAY requires WithTypeLength", name))
+		return b
+	}
+	if elem.ConvertedType != nil && elem.GetConvertedType() == parquet.ConvertedType_DECIMAL {
+		if err := validateDecimalLength(elem); err != nil {
+			b.fail(fmt.Errorf("goparquet: field %q: %w", name, err))
+			return b
+		}
+	}
+
+	b.addField(name, &column{name: name, element: elem})
+	return b
+}
+
+// AddRequired adds a required scalar field of the given physical type.
+func (b *SchemaBuilder) AddRequired(name string, typ parquet.Type, opts ...ElementOption) *SchemaBuilder {
+	return b.addScalar(name, typ, Required, opts)
+}
+
+// AddOptional adds an optional scalar field of the given physical type.
+func (b *SchemaBuilder) AddOptional(name string, typ parquet.Type, opts ...ElementOption) *SchemaBuilder {
+	return b.addScalar(name, typ, Optional, opts)
+}
+
+// AddRepeated adds a repeated scalar field of the given physical type.
+func (b *SchemaBuilder) AddRepeated(name string, typ parquet.Type, opts ...ElementOption) *SchemaBuilder {
+	return b.addScalar(name, typ, Repeated, opts)
+}
+
+// AddDecimal adds a scalar field carrying a DECIMAL annotation with the
+// given precision and scale, choosing INT32/INT64/FIXED_LEN_BYTE_ARRAY as
+// the physical type based on precision.
+func (b *SchemaBuilder) AddDecimal(name string, rep Repetition, precision, scale int32) *SchemaBuilder {
+	typ := decimalPhysicalType(int(precision))
+	opts := []ElementOption{
+		WithConvertedType(parquet.ConvertedType_DECIMAL),
+		WithLogicalType(DecimalLogical(precision, scale)),
+		WithPrecisionScale(precision, scale),
+	}
+	if typ == parquet.Type_FIXED_LEN_BYTE_ARRAY {
+		opts = append(opts, WithTypeLength(int32(decimalLength(int(precision)))))
+	}
+	return b.addScalar(name, typ, rep, opts)
+}
+
+// AddFixedLenByteArray adds a FIXED_LEN_BYTE_ARRAY(length) scalar field.
+func (b *SchemaBuilder) AddFixedLenByteArray(name string, rep Repetition, length int32, opts ...ElementOption) *SchemaBuilder {
+	opts = append([]ElementOption{WithTypeLength(length)}, opts...)
+	return b.addScalar(name, parquet.Type_FIXED_LEN_BYTE_ARRAY, rep, opts)
+}
+
+// StartGroup opens a nested group with the given repetition. Every StartGroup
+// must be matched by a corresponding EndGroup.
+func (b *SchemaBuilder) StartGroup(name string, rep Repetition, opts ...ElementOption) *SchemaBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	elem := &parquet.SchemaElement{
+		Name:           name,
+		RepetitionType: repetitionPtr(rep.fieldRepetitionType()),
+	}
+	for _, opt := range opts {
+		opt(elem)
+	}
+
+	child := &groupBuilder{
+		name:   name,
+		parent: b.group,
+		elem:   elem,
+		names:  make(map[string]bool),
+	}
+	b.group = child
+	return b
+}
+
+// EndGroup closes the group most recently opened with StartGroup, attaching
+// it to its parent group.
+func (b *SchemaBuilder) EndGroup() *SchemaBuilder {
+	if b.err != nil {
+		return b
+	}
+	if b.group.parent == nil {
+		b.fail(fmt.Errorf("goparquet: EndGroup called without a matching StartGroup"))
+		return b
+	}
+
+	closed := b.group
+	b.group = closed.parent
+	b.addField(closed.name, &column{
+		name:     closed.name,
+		element:  closed.elem,
+		children: closed.children,
+	})
+	return b
+}
+
+// AddList adds a LIST-annotated field of element type typ, using the
+// standard 3-level list/element nesting.
+func (b *SchemaBuilder) AddList(name string, typ parquet.Type, opts ...ElementOption) *SchemaBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	elemSchema := &parquet.SchemaElement{
+		Name:           "element",
+		Type:           typePtr(typ),
+		RepetitionType: repetitionPtr(parquet.FieldRepetitionType_REQUIRED),
+	}
+	listGroup := &column{
+		name: "list",
+		element: &parquet.SchemaElement{
+			Name:           "list",
+			RepetitionType: repetitionPtr(parquet.FieldRepetitionType_REPEATED),
+		},
+		children: []*column{{name: "element", element: elemSchema}},
+	}
+
+	listElem := &parquet.SchemaElement{
+		Name:           name,
+		RepetitionType: repetitionPtr(parquet.FieldRepetitionType_OPTIONAL),
+		ConvertedType:  convertedTypePtr(parquet.ConvertedType_LIST),
+		LogicalType:    &parquet.LogicalType{LIST: &parquet.ListType{}},
+	}
+	for _, opt := range opts {
+		opt(listElem)
+	}
+
+	b.addField(name, &column{name: name, element: listElem, children: []*column{listGroup}})
+	return b
+}
+
+// AddMap adds a MAP-annotated field with the given key and value physical
+// types, using the standard key_value/key/value nesting.
+func (b *SchemaBuilder) AddMap(name string, keyType, valueType parquet.Type, opts ...ElementOption) *SchemaBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	keyElem := &parquet.SchemaElement{
+		Name:           "key",
+		Type:           typePtr(keyType),
+		RepetitionType: repetitionPtr(parquet.FieldRepetitionType_REQUIRED),
+	}
+	valueElem := &parquet.SchemaElement{
+		Name:           "value",
+		Type:           typePtr(valueType),
+		RepetitionType: repetitionPtr(parquet.FieldRepetitionType_OPTIONAL),
+	}
+	keyValue := &column{
+		name: "key_value",
+		element: &parquet.SchemaElement{
+			Name:           "key_value",
+			RepetitionType: repetitionPtr(parquet.FieldRepetitionType_REPEATED),
+		},
+		children: []*column{
+			{name: "key", element: keyElem},
+			{name: "value", element: valueElem},
+		},
+	}
+
+	mapElem := &parquet.SchemaElement{
+		Name:           name,
+		RepetitionType: repetitionPtr(parquet.FieldRepetitionType_OPTIONAL),
+		ConvertedType:  convertedTypePtr(parquet.ConvertedType_MAP),
+		LogicalType:    &parquet.LogicalType{MAP: &parquet.MapType{}},
+	}
+	for _, opt := range opts {
+		opt(mapElem)
+	}
+
+	b.addField(name, &column{name: name, element: mapElem, children: []*column{keyValue}})
+	return b
+}
+
+// Build finalizes the SchemaBuilder and returns the resulting
+// SchemaDefinition, or an error if the schema is invalid (unclosed groups,
+// duplicate names, or invalid decimal parameters).
+func (b *SchemaBuilder) Build() (*SchemaDefinition, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.group != b.root {
+		return nil, fmt.Errorf("goparquet: StartGroup %q was never closed with EndGroup", b.group.name)
+	}
+
+	return &SchemaDefinition{
+		col: &column{
+			name:     b.root.name,
+			element:  b.root.elem,
+			children: b.root.children,
+		},
+	}, nil
+}
+
+func convertedTypePtr(ct parquet.ConvertedType) *parquet.ConvertedType {
+	return &ct
+}
+
+// validateLogicalGroupShape checks that a group annotated LIST or MAP (via
+// either AddList/AddMap or a manually-built StartGroup/EndGroup tree) follows
+// the standard nesting and repetition rules: a single REPEATED child group
+// holding either one non-repeated element (LIST) or a REQUIRED key plus a
+// non-repeated value (MAP). Groups without a LIST/MAP annotation are left
+// alone.
+func validateLogicalGroupShape(elem *parquet.SchemaElement, children []*column) error {
+	switch {
+	case isListAnnotated(elem):
+		return validateListShape(children)
+	case isMapAnnotated(elem):
+		return validateMapShape(children)
+	default:
+		return nil
+	}
+}
+
+func isListAnnotated(elem *parquet.SchemaElement) bool {
+	if elem.LogicalType != nil && elem.GetLogicalType().IsSetLIST() {
+		return true
+	}
+	return elem.ConvertedType != nil && elem.GetConvertedType() == parquet.ConvertedType_LIST
+}
+
+func isMapAnnotated(elem *parquet.SchemaElement) bool {
+	if elem.LogicalType != nil && elem.GetLogicalType().IsSetMAP() {
+		return true
+	}
+	return elem.ConvertedType != nil &&
+		(elem.GetConvertedType() == parquet.ConvertedType_MAP || elem.GetConvertedType() == parquet.ConvertedType_MAP_KEY_VALUE)
+}
+
+func validateListShape(children []*column) error {
+	if len(children) != 1 {
+		return fmt.Errorf("LIST group must have exactly one child group, got %d", len(children))
+	}
+	repeated := children[0]
+	if repeated.element.GetRepetitionType() != parquet.FieldRepetitionType_REPEATED {
+		return fmt.Errorf("LIST group's child %q must be repeated", repeated.name)
+	}
+	if len(repeated.children) != 1 {
+		return fmt.Errorf("LIST group's repeated child %q must have exactly one element field, got %d", repeated.name, len(repeated.children))
+	}
+	if repeated.children[0].element.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+		return fmt.Errorf("LIST element %q must not be repeated", repeated.children[0].name)
+	}
+	return nil
+}
+
+func validateMapShape(children []*column) error {
+	if len(children) != 1 {
+		return fmt.Errorf("MAP group must have exactly one child group, got %d", len(children))
+	}
+	repeated := children[0]
+	if repeated.element.GetRepetitionType() != parquet.FieldRepetitionType_REPEATED {
+		return fmt.Errorf("MAP group's child %q must be repeated", repeated.name)
+	}
+	if len(repeated.children) != 2 {
+		return fmt.Errorf("MAP group's repeated child %q must have exactly a key and a value field, got %d", repeated.name, len(repeated.children))
+	}
+	key, value := repeated.children[0], repeated.children[1]
+	if key.element.GetRepetitionType() != parquet.FieldRepetitionType_REQUIRED {
+		return fmt.Errorf("MAP key %q must be required", key.name)
+	}
+	if value.element.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+		return fmt.Errorf("MAP value %q must not be repeated", value.name)
+	}
+	return nil
+}
+
+// validateDecimalLength checks that a FIXED_LEN_BYTE_ARRAY carrying a DECIMAL
+// annotation has enough bytes to hold the declared precision.
+func validateDecimalLength(elem *parquet.SchemaElement) error {
+	if elem.GetType() != parquet.Type_FIXED_LEN_BYTE_ARRAY {
+		return nil
+	}
+	precision := int(elem.GetPrecision())
+	if precision == 0 {
+		return fmt.Errorf("DECIMAL requires a precision (use WithPrecisionScale)")
+	}
+	need := decimalLength(precision)
+	if int(elem.GetTypeLength()) < need {
+		return fmt.Errorf("DECIMAL precision %d needs at least %d bytes, got TypeLength %d", precision, need, elem.GetTypeLength())
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package goparquet
+
+import (
+	"testing"
+
+	"github.com/fraugster/parquet-go/parquet"
+)
+
+func TestSchemaBuilder_AddDecimal_FixedLenByteArrayLength(t *testing.T) {
+	cases := []struct {
+		precision  int32
+		wantLength int32
+	}{
+		{19, 9},
+		{20, 9},
+	}
+	for _, c := range cases {
+		sd, err := NewSchemaBuilder("root").
+			AddDecimal("amount", Required, c.precision, 2).
+			Build()
+		if err != nil {
+			t.Fatalf("precision %d: Build: %v", c.precision, err)
+		}
+
+		elem := sd.SubSchema("amount").SchemaElement()
+		if elem.GetType() != parquet.Type_FIXED_LEN_BYTE_ARRAY {
+			t.Fatalf("precision %d: type = %s, want FIXED_LEN_BYTE_ARRAY", c.precision, elem.GetType())
+		}
+		if elem.GetTypeLength() != c.wantLength {
+			t.Errorf("precision %d: TypeLength = %d, want %d", c.precision, elem.GetTypeLength(), c.wantLength)
+		}
+	}
+}
+
+func TestSchemaBuilder_ValidateDecimalLength_RejectsUndersizedFLBA(t *testing.T) {
+	_, err := NewSchemaBuilder("root").
+		AddFixedLenByteArray("amount", Required, 8,
+			WithConvertedType(parquet.ConvertedType_DECIMAL),
+			WithPrecisionScale(19, 2)).
+		Build()
+	if err == nil {
+		t.Fatal("expected validateDecimalLength to reject an 8-byte FLBA for DECIMAL(19)")
+	}
+}
+
+func TestSchemaBuilder_AutoFieldIDCoversAllNodeKinds(t *testing.T) {
+	sd, err := NewSchemaBuilder("root").
+		AddRequired("id", parquet.Type_INT64).
+		StartGroup("addr", Optional).
+		AddRequired("zip", parquet.Type_INT32).
+		EndGroup().
+		AddList("tags", parquet.Type_BYTE_ARRAY).
+		AddMap("attrs", parquet.Type_BYTE_ARRAY, parquet.Type_BYTE_ARRAY).
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	for _, name := range []string{"id", "addr", "tags", "attrs"} {
+		elem := sd.SubSchema(name).SchemaElement()
+		if elem.FieldID == nil {
+			t.Errorf("%s: expected an automatically assigned FieldID, got none", name)
+		}
+	}
+
+	seen := make(map[int32]string)
+	for _, name := range []string{"id", "addr", "tags", "attrs"} {
+		fid := sd.SubSchema(name).SchemaElement().GetFieldID()
+		if other, ok := seen[fid]; ok {
+			t.Errorf("field ID %d reused by both %q and %q", fid, other, name)
+		}
+		seen[fid] = name
+	}
+}
+
+func TestSchemaBuilder_RejectsMalformedListGroup(t *testing.T) {
+	_, err := NewSchemaBuilder("root").
+		StartGroup("tags", Optional, WithConvertedType(parquet.ConvertedType_LIST), WithLogicalType(&parquet.LogicalType{LIST: &parquet.ListType{}})).
+		AddRequired("element", parquet.Type_BYTE_ARRAY). // missing the intermediate repeated "list" group
+		EndGroup().
+		Build()
+	if err == nil {
+		t.Fatal("expected an error for a LIST group missing its repeated child group")
+	}
+}
+
+func TestSchemaBuilder_RejectsMapWithOptionalKey(t *testing.T) {
+	_, err := NewSchemaBuilder("root").
+		StartGroup("attrs", Optional, WithConvertedType(parquet.ConvertedType_MAP), WithLogicalType(&parquet.LogicalType{MAP: &parquet.MapType{}})).
+		StartGroup("key_value", Repeated).
+		AddOptional("key", parquet.Type_BYTE_ARRAY). // keys must be required
+		AddOptional("value", parquet.Type_BYTE_ARRAY).
+		EndGroup().
+		EndGroup().
+		Build()
+	if err == nil {
+		t.Fatal("expected an error for a MAP group with an optional key")
+	}
+}
+
+func TestSchemaBuilder_WellFormedListAndMapGroupsAccepted(t *testing.T) {
+	_, err := NewSchemaBuilder("root").
+		StartGroup("tags", Optional, WithConvertedType(parquet.ConvertedType_LIST), WithLogicalType(&parquet.LogicalType{LIST: &parquet.ListType{}})).
+		StartGroup("list", Repeated).
+		AddRequired("element", parquet.Type_BYTE_ARRAY).
+		EndGroup().
+		EndGroup().
+		StartGroup("attrs", Optional, WithConvertedType(parquet.ConvertedType_MAP), WithLogicalType(&parquet.LogicalType{MAP: &parquet.MapType{}})).
+		StartGroup("key_value", Repeated).
+		AddRequired("key", parquet.Type_BYTE_ARRAY).
+		AddOptional("value", parquet.Type_BYTE_ARRAY).
+		EndGroup().
+		EndGroup().
+		Build()
+	if err != nil {
+		t.Fatalf("expected well-formed LIST/MAP groups to be accepted, got: %v", err)
+	}
+}
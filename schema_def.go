@@ -62,6 +62,23 @@ type SchemaDefinition struct {
 //		| 'UUID'
 //		| 'ENUM'
 //		| 'JSON'
+//
+// Where a SchemaElement only carries a legacy ConvertedType and no
+// LogicalType (or vice versa), ParseSchemaDefinition synthesizes the missing
+// annotation from the one that is present, so callers can rely on either
+// being populated. The String method prints a field that only carries a
+// ConvertedType with the logical-type token synthesized from it; a group is
+// printed with its ConvertedType only, so a group that carries only a
+// LogicalType prints without a converted-type-annotation. The synthesized
+// LogicalType can carry
+// kinds with no field-level text representation (e.g. DECIMAL, INT, TIME,
+// MAP, LIST, BSON); the String method only ever emits the logical-type
+// tokens above, so that String's output always round-trips through
+// ParseSchemaDefinition. SchemaElements carrying one of the unprintable
+// kinds (typically produced by NewSchemaDefinitionFromStruct, SchemaBuilder,
+// or Parquet file metadata read outside of ParseSchemaDefinition) simply
+// print without a logical-type-annotation.
+//
 //	field-id-definition ::= '=' <number>
 //	number ::= <digit>+
 //	digit ::= '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9'
@@ -76,11 +93,159 @@ func ParseSchemaDefinition(schemaText string) (*SchemaDefinition, error) {
 		return nil, err
 	}
 
+	normalizeConvertedLogicalTypes(p.root)
+
 	return &SchemaDefinition{
 		col: p.root,
 	}, nil
 }
 
+// normalizeConvertedLogicalTypes walks the parsed column tree and, for every
+// SchemaElement that carries a legacy ConvertedType but no LogicalType,
+// synthesizes the equivalent LogicalType (and vice versa), so that callers
+// can rely on either annotation being populated regardless of which one was
+// present in the source text.
+func normalizeConvertedLogicalTypes(col *column) {
+	if col == nil {
+		return
+	}
+
+	elem := col.element
+	if elem != nil {
+		switch {
+		case elem.ConvertedType != nil && elem.LogicalType == nil:
+			elem.LogicalType = convertedToLogicalType(elem.GetConvertedType(), elem)
+		case elem.LogicalType != nil && elem.ConvertedType == nil:
+			if ct, ok := logicalToConvertedType(elem.GetLogicalType()); ok {
+				elem.ConvertedType = &ct
+			}
+		}
+	}
+
+	for _, child := range col.children {
+		normalizeConvertedLogicalTypes(child)
+	}
+}
+
+// convertedToLogicalType synthesizes the LogicalType equivalent to a legacy
+// ConvertedType annotation. elem provides access to the Precision/Scale
+// fields needed for DECIMAL. It returns nil for converted types that have no
+// LogicalType equivalent (e.g. INTERVAL).
+func convertedToLogicalType(ct parquet.ConvertedType, elem *parquet.SchemaElement) *parquet.LogicalType {
+	switch ct {
+	case parquet.ConvertedType_UTF8:
+		return &parquet.LogicalType{STRING: &parquet.StringType{}}
+	case parquet.ConvertedType_MAP, parquet.ConvertedType_MAP_KEY_VALUE:
+		return &parquet.LogicalType{MAP: &parquet.MapType{}}
+	case parquet.ConvertedType_LIST:
+		return &parquet.LogicalType{LIST: &parquet.ListType{}}
+	case parquet.ConvertedType_ENUM:
+		return &parquet.LogicalType{ENUM: &parquet.EnumType{}}
+	case parquet.ConvertedType_JSON:
+		return &parquet.LogicalType{JSON: &parquet.JsonType{}}
+	case parquet.ConvertedType_BSON:
+		return &parquet.LogicalType{BSON: &parquet.BsonType{}}
+	case parquet.ConvertedType_DATE:
+		return &parquet.LogicalType{DATE: &parquet.DateType{}}
+	case parquet.ConvertedType_DECIMAL:
+		return &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: elem.GetPrecision(), Scale: elem.GetScale()}}
+	case parquet.ConvertedType_TIME_MILLIS:
+		return &parquet.LogicalType{TIME: &parquet.TimeType{IsAdjustedToUTC: true, Unit: &parquet.TimeUnit{MILLIS: &parquet.MilliSeconds{}}}}
+	case parquet.ConvertedType_TIME_MICROS:
+		return &parquet.LogicalType{TIME: &parquet.TimeType{IsAdjustedToUTC: true, Unit: &parquet.TimeUnit{MICROS: &parquet.MicroSeconds{}}}}
+	case parquet.ConvertedType_TIMESTAMP_MILLIS:
+		return &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: true, Unit: &parquet.TimeUnit{MILLIS: &parquet.MilliSeconds{}}}}
+	case parquet.ConvertedType_TIMESTAMP_MICROS:
+		return &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: true, Unit: &parquet.TimeUnit{MICROS: &parquet.MicroSeconds{}}}}
+	case parquet.ConvertedType_UINT_8:
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 8, IsSigned: false}}
+	case parquet.ConvertedType_UINT_16:
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 16, IsSigned: false}}
+	case parquet.ConvertedType_UINT_32:
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 32, IsSigned: false}}
+	case parquet.ConvertedType_UINT_64:
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 64, IsSigned: false}}
+	case parquet.ConvertedType_INT_8:
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 8, IsSigned: true}}
+	case parquet.ConvertedType_INT_16:
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 16, IsSigned: true}}
+	case parquet.ConvertedType_INT_32:
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 32, IsSigned: true}}
+	case parquet.ConvertedType_INT_64:
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 64, IsSigned: true}}
+	default:
+		// INTERVAL and any other legacy converted types have no LogicalType
+		// equivalent in the current parquet format.
+		return nil
+	}
+}
+
+// logicalToConvertedType derives the legacy ConvertedType annotation for a
+// LogicalType, for writers and older readers that don't understand
+// LogicalType yet. ok is false when the LogicalType has no legacy
+// equivalent (e.g. UUID).
+func logicalToConvertedType(t *parquet.LogicalType) (parquet.ConvertedType, bool) {
+	switch {
+	case t.IsSetSTRING():
+		return parquet.ConvertedType_UTF8, true
+	case t.IsSetMAP():
+		return parquet.ConvertedType_MAP, true
+	case t.IsSetLIST():
+		return parquet.ConvertedType_LIST, true
+	case t.IsSetENUM():
+		return parquet.ConvertedType_ENUM, true
+	case t.IsSetJSON():
+		return parquet.ConvertedType_JSON, true
+	case t.IsSetBSON():
+		return parquet.ConvertedType_BSON, true
+	case t.IsSetDATE():
+		return parquet.ConvertedType_DATE, true
+	case t.IsSetDECIMAL():
+		return parquet.ConvertedType_DECIMAL, true
+	case t.IsSetTIME():
+		switch {
+		case t.TIME.Unit.IsSetMILLIS():
+			return parquet.ConvertedType_TIME_MILLIS, true
+		case t.TIME.Unit.IsSetMICROS():
+			return parquet.ConvertedType_TIME_MICROS, true
+		default:
+			return 0, false
+		}
+	case t.IsSetTIMESTAMP():
+		switch {
+		case t.TIMESTAMP.Unit.IsSetMILLIS():
+			return parquet.ConvertedType_TIMESTAMP_MILLIS, true
+		case t.TIMESTAMP.Unit.IsSetMICROS():
+			return parquet.ConvertedType_TIMESTAMP_MICROS, true
+		default:
+			return 0, false
+		}
+	case t.IsSetINTEGER():
+		switch {
+		case t.INTEGER.IsSigned && t.INTEGER.BitWidth == 8:
+			return parquet.ConvertedType_INT_8, true
+		case t.INTEGER.IsSigned && t.INTEGER.BitWidth == 16:
+			return parquet.ConvertedType_INT_16, true
+		case t.INTEGER.IsSigned && t.INTEGER.BitWidth == 32:
+			return parquet.ConvertedType_INT_32, true
+		case t.INTEGER.IsSigned && t.INTEGER.BitWidth == 64:
+			return parquet.ConvertedType_INT_64, true
+		case !t.INTEGER.IsSigned && t.INTEGER.BitWidth == 8:
+			return parquet.ConvertedType_UINT_8, true
+		case !t.INTEGER.IsSigned && t.INTEGER.BitWidth == 16:
+			return parquet.ConvertedType_UINT_16, true
+		case !t.INTEGER.IsSigned && t.INTEGER.BitWidth == 32:
+			return parquet.ConvertedType_UINT_32, true
+		case !t.INTEGER.IsSigned && t.INTEGER.BitWidth == 64:
+			return parquet.ConvertedType_UINT_64, true
+		default:
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+}
+
 func (sd *SchemaDefinition) String() string {
 	if sd.col == nil {
 		return "message empty {\n}\n"
@@ -121,6 +286,30 @@ func (sd *SchemaDefinition) SchemaElement() *parquet.SchemaElement {
 	return sd.col.element
 }
 
+// printableFieldAnnotation returns the logical-type-annotation token for a
+// field's LogicalType, and whether it is one the field-definition grammar's
+// logical-type-annotation can actually parse back. Only STRING, DATE,
+// TIMESTAMP, UUID, ENUM and JSON have field-level text syntax; the other
+// kinds synthesized by normalizeConvertedLogicalTypes (DECIMAL, INT, TIME,
+// MAP, LIST, BSON) are left unprinted so that String's output always
+// round-trips through ParseSchemaDefinition. A field that only carries a
+// ConvertedType is printed with the LogicalType synthesized from it.
+func printableFieldAnnotation(elem *parquet.SchemaElement) (string, bool) {
+	lt := elem.LogicalType
+	if lt == nil && elem.ConvertedType != nil {
+		lt = convertedToLogicalType(elem.GetConvertedType(), elem)
+	}
+	if lt == nil {
+		return "", false
+	}
+	switch {
+	case lt.IsSetSTRING(), lt.IsSetDATE(), lt.IsSetTIMESTAMP(), lt.IsSetUUID(), lt.IsSetENUM(), lt.IsSetJSON():
+		return getSchemaLogicalType(lt), true
+	default:
+		return "", false
+	}
+}
+
 func printCols(w io.Writer, cols []*column, indent int) {
 	for _, col := range cols {
 		printIndent(w, indent)
@@ -150,8 +339,8 @@ func printCols(w io.Writer, cols []*column, indent int) {
 		} else {
 			typ := getSchemaType(elem)
 			fmt.Fprintf(w, "%s %s", typ, elem.GetName())
-			if elem.LogicalType != nil {
-				fmt.Fprintf(w, " (%s)", getSchemaLogicalType(elem.GetLogicalType()))
+			if tok, ok := printableFieldAnnotation(elem); ok {
+				fmt.Fprintf(w, " (%s)", tok)
 			}
 			if elem.FieldID != nil {
 				fmt.Fprintf(w, " = %d", elem.GetFieldID())
@@ -200,6 +389,42 @@ func getSchemaConvertedType(t parquet.ConvertedType) string {
 		return "MAP"
 	case parquet.ConvertedType_MAP_KEY_VALUE:
 		return "MAP_KEY_VALUE"
+	case parquet.ConvertedType_ENUM:
+		return "ENUM"
+	case parquet.ConvertedType_DECIMAL:
+		return "DECIMAL"
+	case parquet.ConvertedType_DATE:
+		return "DATE"
+	case parquet.ConvertedType_TIME_MILLIS:
+		return "TIME_MILLIS"
+	case parquet.ConvertedType_TIME_MICROS:
+		return "TIME_MICROS"
+	case parquet.ConvertedType_TIMESTAMP_MILLIS:
+		return "TIMESTAMP_MILLIS"
+	case parquet.ConvertedType_TIMESTAMP_MICROS:
+		return "TIMESTAMP_MICROS"
+	case parquet.ConvertedType_UINT_8:
+		return "UINT_8"
+	case parquet.ConvertedType_UINT_16:
+		return "UINT_16"
+	case parquet.ConvertedType_UINT_32:
+		return "UINT_32"
+	case parquet.ConvertedType_UINT_64:
+		return "UINT_64"
+	case parquet.ConvertedType_INT_8:
+		return "INT_8"
+	case parquet.ConvertedType_INT_16:
+		return "INT_16"
+	case parquet.ConvertedType_INT_32:
+		return "INT_32"
+	case parquet.ConvertedType_INT_64:
+		return "INT_64"
+	case parquet.ConvertedType_JSON:
+		return "JSON"
+	case parquet.ConvertedType_BSON:
+		return "BSON"
+	case parquet.ConvertedType_INTERVAL:
+		return "INTERVAL"
 	}
 	return fmt.Sprintf("UC:%s", t)
 }
@@ -229,6 +454,29 @@ func getSchemaLogicalType(t *parquet.LogicalType) string {
 		return "ENUM"
 	case t.IsSetJSON():
 		return "JSON"
+	case t.IsSetBSON():
+		return "BSON"
+	case t.IsSetMAP():
+		return "MAP"
+	case t.IsSetLIST():
+		return "LIST"
+	case t.IsSetDECIMAL():
+		return fmt.Sprintf("DECIMAL(%d,%d)", t.DECIMAL.Precision, t.DECIMAL.Scale)
+	case t.IsSetINTEGER():
+		return fmt.Sprintf("INT(%d,%t)", t.INTEGER.BitWidth, t.INTEGER.IsSigned)
+	case t.IsSetTIME():
+		unit := ""
+		switch {
+		case t.TIME.Unit.IsSetNANOS():
+			unit = "NANOS"
+		case t.TIME.Unit.IsSetMICROS():
+			unit = "MICROS"
+		case t.TIME.Unit.IsSetMILLIS():
+			unit = "MILLIS"
+		default:
+			unit = "BUG_UNKNOWN_TIME_UNIT"
+		}
+		return fmt.Sprintf("TIME(%s, %t)", unit, t.TIME.IsAdjustedToUTC)
 	default:
 		return "BUG(UNKNOWN)"
 	}
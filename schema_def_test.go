@@ -0,0 +1,69 @@
+package goparquet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fraugster/parquet-go/parquet"
+)
+
+// TestParseSchemaDefinition_RoundTrip exercises Parse(String(x)) == x for the
+// logical-type tokens the field-definition grammar actually supports, plus
+// the legacy converted-type tokens supported on groups. Every annotation
+// printed by String must be parseable again, or normalizeConvertedLogicalTypes
+// and printCols have drifted apart.
+func TestParseSchemaDefinition_RoundTrip(t *testing.T) {
+	const text = `message root {
+  optional binary name (STRING);
+  optional binary created (DATE);
+  optional int64 updated (TIMESTAMP(MICROS, true));
+  optional group legacy (UINT_8) {
+    required int32 v;
+  }
+}
+`
+	sd, err := ParseSchemaDefinition(text)
+	if err != nil {
+		t.Fatalf("ParseSchemaDefinition: %v", err)
+	}
+
+	again, err := ParseSchemaDefinition(sd.String())
+	if err != nil {
+		t.Fatalf("ParseSchemaDefinition(sd.String()) failed to round-trip: %v\n%s", err, sd.String())
+	}
+
+	if !sd.Equal(again) {
+		t.Errorf("round-trip mismatch:\nbefore:\n%s\nafter:\n%s\ndiff: %v", sd.String(), again.String(), sd.Diff(again))
+	}
+}
+
+// TestPrintCols_UnprintableFieldAnnotationOmitted verifies that a field whose
+// LogicalType has no field-level text syntax (e.g. the INTEGER kind
+// synthesized from a legacy INT_8 ConvertedType) prints without an
+// annotation, rather than emitting a token the parser can't read back. This
+// is the behavior that keeps String's output always parseable by
+// ParseSchemaDefinition.
+func TestPrintCols_UnprintableFieldAnnotationOmitted(t *testing.T) {
+	ct := parquet.ConvertedType_INT_8
+	elem := &parquet.SchemaElement{
+		Name:           "small",
+		Type:           typePtr(parquet.Type_INT32),
+		RepetitionType: repetitionPtr(parquet.FieldRepetitionType_REQUIRED),
+		ConvertedType:  &ct,
+		LogicalType:    &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 8, IsSigned: true}},
+	}
+	sd := &SchemaDefinition{col: &column{
+		name:     "root",
+		element:  &parquet.SchemaElement{Name: "root"},
+		children: []*column{{name: "small", element: elem}},
+	}}
+
+	out := sd.String()
+	if strings.Contains(out, "INT(") {
+		t.Errorf("String() printed an unparseable INT(...) annotation:\n%s", out)
+	}
+
+	if _, err := ParseSchemaDefinition(out); err != nil {
+		t.Errorf("ParseSchemaDefinition(%q): %v", out, err)
+	}
+}
@@ -0,0 +1,423 @@
+package goparquet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fraugster/parquet-go/parquet"
+)
+
+// EqualOptions configures how SchemaDefinition.Equal compares two schemas.
+type EqualOptions struct {
+	// IgnoreFieldID, when true, excludes FieldID from the comparison.
+	IgnoreFieldID bool
+}
+
+// Equal reports whether sd and other describe the same schema, i.e. whether
+// Diff reports no differences. By default field IDs are compared; pass
+// EqualOptions to relax that. Fields are matched by name, so neither the
+// order of fields within a group nor the name of the root message is
+// compared.
+func (sd *SchemaDefinition) Equal(other *SchemaDefinition, opts ...EqualOptions) bool {
+	var opt EqualOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return len(sd.Diff(other, opt)) == 0
+}
+
+// DiffKind identifies the kind of change a SchemaDiff entry describes.
+type DiffKind int
+
+// Supported diff kinds.
+const (
+	DiffAdded DiffKind = iota
+	DiffRemoved
+	DiffTypeChanged
+	DiffRepetitionChanged
+	DiffAnnotationChanged
+	DiffDecimalChanged
+	DiffFieldIDChanged
+)
+
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffTypeChanged:
+		return "type changed"
+	case DiffRepetitionChanged:
+		return "repetition changed"
+	case DiffAnnotationChanged:
+		return "annotation changed"
+	case DiffDecimalChanged:
+		return "decimal precision/scale changed"
+	case DiffFieldIDChanged:
+		return "field ID changed"
+	default:
+		return "unknown"
+	}
+}
+
+// SchemaDiff describes a single difference between two schemas at Path.
+type SchemaDiff struct {
+	Path string
+	Kind DiffKind
+	Old  string
+	New  string
+}
+
+func (d SchemaDiff) String() string {
+	if d.Old == "" && d.New == "" {
+		return fmt.Sprintf("%s: %s", d.Path, d.Kind)
+	}
+	return fmt.Sprintf("%s: %s (%s -> %s)", d.Path, d.Kind, d.Old, d.New)
+}
+
+// Diff compares sd against other and returns the list of structural
+// differences between them, in a deterministic, depth-first order. An empty
+// result means the two schemas are equal under opt.
+func (sd *SchemaDefinition) Diff(other *SchemaDefinition, opts ...EqualOptions) []SchemaDiff {
+	var opt EqualOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	var diffs []SchemaDiff
+	diffColumns(diffRootName(sd, other), schemaCol(sd), schemaCol(other), opt, &diffs)
+	return diffs
+}
+
+// schemaCol returns sd's underlying column, tolerating a nil receiver or a
+// zero-value SchemaDefinition{} (both of which String() already treats as an
+// empty schema).
+func schemaCol(sd *SchemaDefinition) *column {
+	if sd == nil {
+		return nil
+	}
+	return sd.col
+}
+
+// diffRootName picks the path prefix for a Diff/Equal call, falling back to
+// either side's column name so that comparing against a nil or empty
+// SchemaDefinition doesn't panic.
+func diffRootName(sd, other *SchemaDefinition) string {
+	if c := schemaCol(sd); c != nil {
+		return c.Name()
+	}
+	if c := schemaCol(other); c != nil {
+		return c.Name()
+	}
+	return "message"
+}
+
+func diffColumns(path string, a, b *column, opt EqualOptions, diffs *[]SchemaDiff) {
+	if a == nil && b == nil {
+		return
+	}
+	if a == nil {
+		*diffs = append(*diffs, SchemaDiff{Path: path, Kind: DiffAdded})
+		return
+	}
+	if b == nil {
+		*diffs = append(*diffs, SchemaDiff{Path: path, Kind: DiffRemoved})
+		return
+	}
+
+	diffElements(path, a.element, b.element, opt, diffs)
+
+	bChildren := make(map[string]*column, len(b.children))
+	for _, c := range b.children {
+		bChildren[c.name] = c
+	}
+	seen := make(map[string]bool, len(a.children))
+
+	for _, ca := range a.children {
+		seen[ca.name] = true
+		diffColumns(path+"."+ca.name, ca, bChildren[ca.name], opt, diffs)
+	}
+	for _, cb := range b.children {
+		if !seen[cb.name] {
+			diffColumns(path+"."+cb.name, nil, cb, opt, diffs)
+		}
+	}
+}
+
+func diffElements(path string, a, b *parquet.SchemaElement, opt EqualOptions, diffs *[]SchemaDiff) {
+	if a == nil || b == nil {
+		return
+	}
+
+	if a.GetType() != b.GetType() || a.GetTypeLength() != b.GetTypeLength() {
+		*diffs = append(*diffs, SchemaDiff{
+			Path: path,
+			Kind: DiffTypeChanged,
+			Old:  physicalTypeString(a),
+			New:  physicalTypeString(b),
+		})
+	}
+
+	if a.GetRepetitionType() != b.GetRepetitionType() {
+		*diffs = append(*diffs, SchemaDiff{
+			Path: path,
+			Kind: DiffRepetitionChanged,
+			Old:  a.GetRepetitionType().String(),
+			New:  b.GetRepetitionType().String(),
+		})
+	}
+
+	if a.GetPrecision() != b.GetPrecision() || a.GetScale() != b.GetScale() {
+		*diffs = append(*diffs, SchemaDiff{
+			Path: path,
+			Kind: DiffDecimalChanged,
+			Old:  fmt.Sprintf("precision=%d,scale=%d", a.GetPrecision(), a.GetScale()),
+			New:  fmt.Sprintf("precision=%d,scale=%d", b.GetPrecision(), b.GetScale()),
+		})
+	}
+
+	if annotationString(a) != annotationString(b) {
+		*diffs = append(*diffs, SchemaDiff{
+			Path: path,
+			Kind: DiffAnnotationChanged,
+			Old:  annotationString(a),
+			New:  annotationString(b),
+		})
+	}
+
+	if !opt.IgnoreFieldID && a.GetFieldID() != b.GetFieldID() {
+		*diffs = append(*diffs, SchemaDiff{
+			Path: path,
+			Kind: DiffFieldIDChanged,
+			Old:  fmt.Sprintf("%d", a.GetFieldID()),
+			New:  fmt.Sprintf("%d", b.GetFieldID()),
+		})
+	}
+}
+
+func physicalTypeString(elem *parquet.SchemaElement) string {
+	if elem.Type == nil {
+		return "group"
+	}
+	return getSchemaType(elem)
+}
+
+func annotationString(elem *parquet.SchemaElement) string {
+	var parts []string
+	if elem.ConvertedType != nil {
+		parts = append(parts, "converted="+getSchemaConvertedType(elem.GetConvertedType()))
+	}
+	if elem.LogicalType != nil {
+		parts = append(parts, "logical="+getSchemaLogicalType(elem.GetLogicalType()))
+	}
+	return strings.Join(parts, ",")
+}
+
+// MergePolicy configures how SchemaDefinition.Merge reconciles two schemas.
+type MergePolicy struct {
+	// CoerceIntWidths allows merging an INT32 field with an INT64 field by
+	// widening to INT64 (e.g. INT_8 with INT_64). Sized-integer annotations
+	// on fields of the same physical type (e.g. INT_8 with INT_32) are
+	// widened to the larger bit width regardless of this setting.
+	CoerceIntWidths bool
+}
+
+// Merge reconciles sd with other according to policy, producing a schema
+// that both can be read as. Fields present in only one of the two schemas
+// are included as optional; required fields that appear as optional (or
+// vice versa) on the other side are merged as optional. Fields with
+// incompatible physical types are rejected, unless policy allows coercing
+// compatible integer widths.
+func (sd *SchemaDefinition) Merge(other *SchemaDefinition, policy MergePolicy) (*SchemaDefinition, error) {
+	aCol, bCol := schemaCol(sd), schemaCol(other)
+	if aCol == nil && bCol == nil {
+		return &SchemaDefinition{}, nil
+	}
+
+	merged, err := mergeColumns(aCol, bCol, policy)
+	if err != nil {
+		return nil, fmt.Errorf("goparquet: merging schemas: %w", err)
+	}
+	return &SchemaDefinition{col: merged}, nil
+}
+
+func mergeColumns(a, b *column, policy MergePolicy) (*column, error) {
+	if a == nil {
+		return promoteOptional(b), nil
+	}
+	if b == nil {
+		return promoteOptional(a), nil
+	}
+
+	elem, err := mergeElements(a.name, a.element, b.element, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	bChildren := make(map[string]*column, len(b.children))
+	bOrder := make([]string, 0, len(b.children))
+	for _, c := range b.children {
+		bChildren[c.name] = c
+		bOrder = append(bOrder, c.name)
+	}
+	seen := make(map[string]bool, len(a.children))
+
+	var children []*column
+	for _, ca := range a.children {
+		seen[ca.name] = true
+		merged, err := mergeColumns(ca, bChildren[ca.name], policy)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", ca.name, err)
+		}
+		children = append(children, merged)
+	}
+	for _, name := range bOrder {
+		if seen[name] {
+			continue
+		}
+		merged, err := mergeColumns(nil, bChildren[name], policy)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		children = append(children, merged)
+	}
+
+	return &column{name: a.name, element: elem, children: children}, nil
+}
+
+// promoteOptional returns a copy of col's tree with its top-level repetition
+// forced to OPTIONAL, used for fields that only appear on one side of a
+// Merge.
+func promoteOptional(col *column) *column {
+	elem := *col.element
+	elem.RepetitionType = repetitionPtr(parquet.FieldRepetitionType_OPTIONAL)
+	return &column{name: col.name, element: &elem, children: col.children}
+}
+
+func mergeElements(name string, a, b *parquet.SchemaElement, policy MergePolicy) (*parquet.SchemaElement, error) {
+	merged := *a
+
+	if a.Type == nil || b.Type == nil {
+		if a.Type != nil || b.Type != nil {
+			return nil, fmt.Errorf("field %q is a group on one side and a scalar on the other", name)
+		}
+	} else if a.GetType() != b.GetType() {
+		coerced, ok := coerceIntType(a, b, policy)
+		if !ok {
+			return nil, fmt.Errorf("field %q has incompatible types %s and %s", name, getSchemaType(a), getSchemaType(b))
+		}
+		merged.Type = coerced
+		if err := reconcileIntAnnotation(&merged, a, b); err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+	} else if a.GetTypeLength() != b.GetTypeLength() {
+		return nil, fmt.Errorf("field %q has incompatible types %s and %s", name, getSchemaType(a), getSchemaType(b))
+	} else if err := reconcileEqualTypeIntAnnotation(&merged, a, b); err != nil {
+		return nil, fmt.Errorf("field %q: %w", name, err)
+	}
+
+	if a.GetRepetitionType() != b.GetRepetitionType() {
+		ra, rb := a.GetRepetitionType(), b.GetRepetitionType()
+		if ra == parquet.FieldRepetitionType_REPEATED || rb == parquet.FieldRepetitionType_REPEATED {
+			if ra != rb {
+				return nil, fmt.Errorf("field %q is repeated on one side but not the other", name)
+			}
+		}
+		merged.RepetitionType = repetitionPtr(parquet.FieldRepetitionType_OPTIONAL)
+	}
+
+	return &merged, nil
+}
+
+// intAnnotationSignedness checks that a and b either both lack a
+// sized-integer annotation, or both carry one with matching signedness. ok is
+// false when neither side has one, in which case there is nothing to
+// reconcile.
+func intAnnotationSignedness(a, b *parquet.SchemaElement) (ok bool, signed bool, err error) {
+	aInt := a.LogicalType != nil && a.GetLogicalType().IsSetINTEGER()
+	bInt := b.LogicalType != nil && b.GetLogicalType().IsSetINTEGER()
+
+	if !aInt && !bInt {
+		return false, false, nil
+	}
+	if aInt != bInt {
+		return false, false, fmt.Errorf("one side has a sized-integer annotation and the other does not")
+	}
+	if a.LogicalType.INTEGER.IsSigned != b.LogicalType.INTEGER.IsSigned {
+		return false, false, fmt.Errorf("cannot merge signed and unsigned integer annotations")
+	}
+	return true, a.LogicalType.INTEGER.IsSigned, nil
+}
+
+// reconcileIntAnnotation updates merged's sized-integer LogicalType/
+// ConvertedType annotation (if any) to match the physical type widened by
+// coerceIntType, so the merged element never advertises a bit width
+// narrower than its actual physical type (e.g. an INT_8 widened to INT64
+// comes out annotated INT_64, not the stale INT_8). It rejects merges where
+// only one side carries a sized-integer annotation, or where the two sides
+// disagree on signedness.
+func reconcileIntAnnotation(merged, a, b *parquet.SchemaElement) error {
+	ok, signed, err := intAnnotationSignedness(a, b)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// Neither side carries a sized-integer annotation; a bare INT32/INT64
+		// widens with no annotation to reconcile.
+		return nil
+	}
+
+	bitWidth := int8(32)
+	if merged.GetType() == parquet.Type_INT64 {
+		bitWidth = 64
+	}
+	setIntLogical(merged, bitWidth, signed)
+	return nil
+}
+
+// reconcileEqualTypeIntAnnotation updates merged's sized-integer annotation
+// when a and b already share the same physical type but disagree on the
+// advertised bit width (e.g. two INT32 fields annotated INT_8 and INT_16,
+// the shape struct-derived int8/int16 fields take), widening to the larger
+// of the two so the merged field doesn't silently claim a narrower range
+// than either side actually holds. Mirrors reconcileIntAnnotation's
+// signedness handling.
+func reconcileEqualTypeIntAnnotation(merged, a, b *parquet.SchemaElement) error {
+	ok, signed, err := intAnnotationSignedness(a, b)
+	if err != nil {
+		return err
+	}
+	if !ok || a.LogicalType.INTEGER.BitWidth == b.LogicalType.INTEGER.BitWidth {
+		return nil
+	}
+
+	bitWidth := a.LogicalType.INTEGER.BitWidth
+	if b.LogicalType.INTEGER.BitWidth > bitWidth {
+		bitWidth = b.LogicalType.INTEGER.BitWidth
+	}
+	setIntLogical(merged, bitWidth, signed)
+	return nil
+}
+
+// coerceIntType widens two differing INT32/INT64 physical types to the
+// larger of the two, when policy allows it and both sides are INT32 or
+// INT64. Signedness is not checked here; reconcileIntAnnotation rejects a
+// signedness mismatch afterwards.
+func coerceIntType(a, b *parquet.SchemaElement, policy MergePolicy) (*parquet.Type, bool) {
+	if !policy.CoerceIntWidths {
+		return nil, false
+	}
+	isInt := func(t parquet.Type) bool {
+		return t == parquet.Type_INT32 || t == parquet.Type_INT64
+	}
+	if !isInt(a.GetType()) || !isInt(b.GetType()) {
+		return nil, false
+	}
+	if a.GetType() == parquet.Type_INT64 || b.GetType() == parquet.Type_INT64 {
+		t := parquet.Type_INT64
+		return &t, true
+	}
+	t := parquet.Type_INT32
+	return &t, true
+}
@@ -0,0 +1,117 @@
+package goparquet
+
+import (
+	"testing"
+
+	"github.com/fraugster/parquet-go/parquet"
+)
+
+func TestSchemaDefinition_DiffEqual_NilSafe(t *testing.T) {
+	empty := &SchemaDefinition{}
+
+	if !empty.Equal(empty) {
+		t.Error("an empty SchemaDefinition should equal itself")
+	}
+
+	var nilReceiver *SchemaDefinition
+	if !nilReceiver.Equal(nilReceiver) {
+		t.Error("a nil *SchemaDefinition should equal itself")
+	}
+	if diffs := nilReceiver.Diff(empty); len(diffs) != 0 {
+		t.Errorf("nil vs empty SchemaDefinition should have no diffs, got %v", diffs)
+	}
+}
+
+func intSchema(t *testing.T, bitWidth int8, signed bool) *SchemaDefinition {
+	t.Helper()
+	typ := parquet.Type_INT32
+	if bitWidth == 64 {
+		typ = parquet.Type_INT64
+	}
+	sd, err := NewSchemaBuilder("root").
+		AddRequired("v", typ, WithLogicalType(&parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: bitWidth, IsSigned: signed}})).
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return sd
+}
+
+func TestSchemaDefinition_Merge_WidensIntAnnotation(t *testing.T) {
+	a := intSchema(t, 8, true)
+	b := intSchema(t, 64, true)
+
+	merged, err := a.Merge(b, MergePolicy{CoerceIntWidths: true})
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	elem := merged.SubSchema("v").SchemaElement()
+	if elem.GetType() != parquet.Type_INT64 {
+		t.Fatalf("v: type = %s, want INT64", elem.GetType())
+	}
+	if elem.LogicalType.INTEGER.BitWidth != 64 {
+		t.Errorf("v: widened element still annotated with bit width %d, want 64", elem.LogicalType.INTEGER.BitWidth)
+	}
+	if elem.GetConvertedType() != parquet.ConvertedType_INT_64 {
+		t.Errorf("v: ConvertedType = %s, want INT_64", elem.GetConvertedType())
+	}
+}
+
+func TestSchemaDefinition_Merge_RejectsSignednessMismatch(t *testing.T) {
+	a := intSchema(t, 8, true)
+	b := intSchema(t, 64, false)
+
+	if _, err := a.Merge(b, MergePolicy{CoerceIntWidths: true}); err == nil {
+		t.Error("expected Merge to reject signed/unsigned integer annotation mismatch")
+	}
+}
+
+func int32Schema(t *testing.T, bitWidth int8, signed bool) *SchemaDefinition {
+	t.Helper()
+	sd, err := NewSchemaBuilder("root").
+		AddRequired("v", parquet.Type_INT32, WithLogicalType(&parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: bitWidth, IsSigned: signed}})).
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	return sd
+}
+
+func TestSchemaDefinition_Merge_WidensAnnotationOnEqualPhysicalType(t *testing.T) {
+	// Both fields are physically INT32 (e.g. struct-derived int8 and int16),
+	// so neither the coerce nor the widen-on-type-change path runs; the
+	// annotation itself must still widen to avoid advertising a narrower
+	// bit width than one side actually holds.
+	a := int32Schema(t, 8, true)
+	b := int32Schema(t, 16, true)
+
+	merged, err := a.Merge(b, MergePolicy{})
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	elem := merged.SubSchema("v").SchemaElement()
+	if elem.GetType() != parquet.Type_INT32 {
+		t.Fatalf("v: type = %s, want INT32", elem.GetType())
+	}
+	if elem.LogicalType.INTEGER.BitWidth != 16 {
+		t.Errorf("v: merged element annotated with bit width %d, want 16 (widened from 8 and 16)", elem.LogicalType.INTEGER.BitWidth)
+	}
+	if elem.GetConvertedType() != parquet.ConvertedType_INT_16 {
+		t.Errorf("v: ConvertedType = %s, want INT_16", elem.GetConvertedType())
+	}
+}
+
+func TestSchemaDefinition_Merge_NilSafe(t *testing.T) {
+	empty := &SchemaDefinition{}
+
+	if _, err := empty.Merge(empty, MergePolicy{}); err != nil {
+		t.Errorf("Merge of two empty schemas should not error, got: %v", err)
+	}
+
+	var nilReceiver *SchemaDefinition
+	if _, err := nilReceiver.Merge(nilReceiver, MergePolicy{}); err != nil {
+		t.Errorf("Merge on a nil receiver should not panic or error, got: %v", err)
+	}
+}
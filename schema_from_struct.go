@@ -0,0 +1,730 @@
+package goparquet
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fraugster/parquet-go/parquet"
+)
+
+// NewSchemaDefinitionFromStruct derives a SchemaDefinition from a Go value by
+// walking its type via reflection. v is typically a pointer to a struct, or
+// a struct value directly. Field tags of the form
+//
+//	`parquet:"name=...,type=...,convertedtype=...,logicaltype=...,precision=...,scale=...,length=...,fieldid=...,utc=..."`
+//
+// can be used to override the defaults that are derived from the Go type.
+// type overrides the physical encoding (e.g. "INT64", "FIXED_LEN_BYTE_ARRAY");
+// convertedtype/logicaltype override the annotation (e.g. "INT_8"; NANOS
+// units such as "TIMESTAMP_NANOS" are accepted by logicaltype only, as they
+// have no ConvertedType); length overrides TypeLength; utc overrides whether a
+// time.Time or TIMESTAMP/TIME annotation is adjusted to UTC.
+//
+// Without tag overrides, Go types map onto the schema as follows:
+//
+//	bool                   -> boolean
+//	int8, int16            -> int32 (INT_8, INT_16)
+//	int32                  -> int32
+//	int, int64             -> int64
+//	uint8, uint16, uint32  -> int32 (UINT_8, UINT_16, UINT_32)
+//	uint, uint64           -> int64 (UINT_64)
+//	float32, float64       -> float, double
+//	string                 -> binary (UTF8 / STRING)
+//	[]byte                 -> binary
+//	[N]byte                -> fixed_len_byte_array(N)
+//	time.Time              -> int64 with a TIMESTAMP(MICROS, UTC) logical type
+//	big.Rat                -> DECIMAL, requires a precision tag; int32 up to 9
+//	                          digits, int64 up to 18, fixed_len_byte_array beyond
+//	*T                     -> T, made optional
+//	[]T                    -> optional LIST group (list/element nesting)
+//	map[K]V                -> optional MAP group (key_value/key/value nesting)
+//	struct                 -> required group of the struct's exported fields
+//
+// This provides a programmatic counterpart to ParseSchemaDefinition for
+// callers that would rather describe their schema using Go structs than a
+// textual schema definition.
+func NewSchemaDefinitionFromStruct(v interface{}) (*SchemaDefinition, error) {
+	if v == nil {
+		return nil, fmt.Errorf("goparquet: cannot derive schema from nil value")
+	}
+	return NewSchemaDefinitionFromType(reflect.TypeOf(v))
+}
+
+// NewSchemaDefinitionFromType derives a SchemaDefinition from a reflect.Type.
+// t must be a struct or a pointer to a struct; see NewSchemaDefinitionFromStruct
+// for details on the supported type mapping and struct tags.
+func NewSchemaDefinitionFromType(t reflect.Type) (*SchemaDefinition, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("goparquet: NewSchemaDefinitionFromType requires a struct or pointer to struct, got %s", t.Kind())
+	}
+
+	name := t.Name()
+	if name == "" {
+		name = "msg"
+	}
+
+	children, err := structFieldsToColumns(t)
+	if err != nil {
+		return nil, fmt.Errorf("goparquet: deriving schema from %s: %w", t, err)
+	}
+
+	return &SchemaDefinition{
+		col: &column{
+			name:     name,
+			element:  &parquet.SchemaElement{Name: name},
+			children: children,
+		},
+	}, nil
+}
+
+// structTag holds the parsed contents of a `parquet:"..."` struct tag.
+type structTag struct {
+	Name          string
+	Type          string
+	ConvertedType string
+	LogicalType   string
+	Precision     int
+	Scale         int
+	Length        int
+	FieldID       int
+	HasFieldID    bool
+	UTC           *bool
+	Skip          bool
+}
+
+func parseStructTag(sf reflect.StructField) (structTag, error) {
+	tag := structTag{Name: sf.Name}
+
+	raw, ok := sf.Tag.Lookup("parquet")
+	if !ok {
+		return tag, nil
+	}
+	if raw == "-" {
+		tag.Skip = true
+		return tag, nil
+	}
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		key := strings.ToLower(strings.TrimSpace(kv[0]))
+		var value string
+		if len(kv) == 2 {
+			value = strings.TrimSpace(kv[1])
+		}
+		switch key {
+		case "name":
+			tag.Name = value
+		case "type":
+			tag.Type = strings.ToUpper(value)
+		case "convertedtype":
+			tag.ConvertedType = strings.ToUpper(value)
+		case "logicaltype":
+			tag.LogicalType = strings.ToUpper(value)
+		case "precision":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return tag, fmt.Errorf("field %s: invalid precision %q: %w", sf.Name, value, err)
+			}
+			tag.Precision = n
+		case "scale":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return tag, fmt.Errorf("field %s: invalid scale %q: %w", sf.Name, value, err)
+			}
+			tag.Scale = n
+		case "length":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return tag, fmt.Errorf("field %s: invalid length %q: %w", sf.Name, value, err)
+			}
+			tag.Length = n
+		case "fieldid":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return tag, fmt.Errorf("field %s: invalid fieldid %q: %w", sf.Name, value, err)
+			}
+			tag.FieldID = n
+			tag.HasFieldID = true
+		case "utc":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return tag, fmt.Errorf("field %s: invalid utc %q: %w", sf.Name, value, err)
+			}
+			tag.UTC = &b
+		default:
+			// unknown tag keys are ignored so that tags shared with other
+			// encoders/decoders don't cause derivation to fail.
+		}
+	}
+
+	return tag, nil
+}
+
+var (
+	timeTimeType = reflect.TypeOf(time.Time{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+)
+
+func structFieldsToColumns(t reflect.Type) ([]*column, error) {
+	cols := make([]*column, 0, t.NumField())
+	seen := make(map[string]bool, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			// unexported field, nothing we can read via reflection.
+			continue
+		}
+
+		tag, err := parseStructTag(sf)
+		if err != nil {
+			return nil, err
+		}
+		if tag.Skip {
+			continue
+		}
+
+		col, err := fieldToColumn(tag.Name, sf.Type, tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+		if seen[col.name] {
+			return nil, fmt.Errorf("duplicate field name %q", col.name)
+		}
+		seen[col.name] = true
+		if tag.HasFieldID {
+			fid := int32(tag.FieldID)
+			col.element.FieldID = &fid
+		}
+
+		cols = append(cols, col)
+	}
+
+	return cols, nil
+}
+
+func fieldToColumn(name string, t reflect.Type, tag structTag) (*column, error) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		col, err := fieldToColumn(name, t.Elem(), tag)
+		if err != nil {
+			return nil, err
+		}
+		setRepetition(col.element, parquet.FieldRepetitionType_OPTIONAL)
+		return col, nil
+
+	case reflect.Map:
+		return mapToColumn(name, t, tag)
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte maps to a binary scalar rather than a repeated group.
+			return scalarToColumn(name, t, tag, parquet.FieldRepetitionType_REQUIRED)
+		}
+		return listToColumn(name, t, tag)
+
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return scalarToColumn(name, t, tag, parquet.FieldRepetitionType_REQUIRED)
+		}
+		return nil, fmt.Errorf("unsupported array element type %s", t.Elem())
+
+	case reflect.Struct:
+		if t == timeTimeType || t == bigRatType {
+			return scalarToColumn(name, t, tag, parquet.FieldRepetitionType_REQUIRED)
+		}
+		children, err := structFieldsToColumns(t)
+		if err != nil {
+			return nil, err
+		}
+		return &column{
+			name: name,
+			element: &parquet.SchemaElement{
+				Name:           name,
+				RepetitionType: repetitionPtr(parquet.FieldRepetitionType_REQUIRED),
+			},
+			children: children,
+		}, nil
+
+	default:
+		return scalarToColumn(name, t, tag, parquet.FieldRepetitionType_REQUIRED)
+	}
+}
+
+func setRepetition(elem *parquet.SchemaElement, rt parquet.FieldRepetitionType) {
+	elem.RepetitionType = repetitionPtr(rt)
+}
+
+func repetitionPtr(rt parquet.FieldRepetitionType) *parquet.FieldRepetitionType {
+	return &rt
+}
+
+// listToColumn builds the standard 3-level LIST group:
+//
+//	optional group <name> (LIST) {
+//	  repeated group list {
+//	    <element>;
+//	  }
+//	}
+func listToColumn(name string, t reflect.Type, tag structTag) (*column, error) {
+	elemCol, err := fieldToColumn("element", t.Elem(), structTag{Name: "element"})
+	if err != nil {
+		return nil, fmt.Errorf("list element: %w", err)
+	}
+	setRepetition(elemCol.element, parquet.FieldRepetitionType_REQUIRED)
+
+	listGroup := &column{
+		name: "list",
+		element: &parquet.SchemaElement{
+			Name:           "list",
+			RepetitionType: repetitionPtr(parquet.FieldRepetitionType_REPEATED),
+		},
+		children: []*column{elemCol},
+	}
+
+	ct := parquet.ConvertedType_LIST
+	return &column{
+		name: name,
+		element: &parquet.SchemaElement{
+			Name:           name,
+			RepetitionType: repetitionPtr(parquet.FieldRepetitionType_OPTIONAL),
+			ConvertedType:  &ct,
+			LogicalType:    &parquet.LogicalType{LIST: &parquet.ListType{}},
+		},
+		children: []*column{listGroup},
+	}, nil
+}
+
+// mapToColumn builds the standard MAP group with its key_value/key/value
+// nesting:
+//
+//	optional group <name> (MAP) {
+//	  repeated group key_value {
+//	    required <key-type> key;
+//	    <value-type> value;
+//	  }
+//	}
+func mapToColumn(name string, t reflect.Type, tag structTag) (*column, error) {
+	keyCol, err := fieldToColumn("key", t.Key(), structTag{Name: "key"})
+	if err != nil {
+		return nil, fmt.Errorf("map key: %w", err)
+	}
+	setRepetition(keyCol.element, parquet.FieldRepetitionType_REQUIRED)
+
+	valCol, err := fieldToColumn("value", t.Elem(), structTag{Name: "value"})
+	if err != nil {
+		return nil, fmt.Errorf("map value: %w", err)
+	}
+
+	keyValue := &column{
+		name: "key_value",
+		element: &parquet.SchemaElement{
+			Name:           "key_value",
+			RepetitionType: repetitionPtr(parquet.FieldRepetitionType_REPEATED),
+		},
+		children: []*column{keyCol, valCol},
+	}
+
+	ct := parquet.ConvertedType_MAP
+	return &column{
+		name: name,
+		element: &parquet.SchemaElement{
+			Name:           name,
+			RepetitionType: repetitionPtr(parquet.FieldRepetitionType_OPTIONAL),
+			ConvertedType:  &ct,
+			LogicalType:    &parquet.LogicalType{MAP: &parquet.MapType{}},
+		},
+		children: []*column{keyValue},
+	}, nil
+}
+
+func scalarToColumn(name string, t reflect.Type, tag structTag, rt parquet.FieldRepetitionType) (*column, error) {
+	elem, err := scalarSchemaElement(name, t, tag)
+	if err != nil {
+		return nil, err
+	}
+	elem.RepetitionType = repetitionPtr(rt)
+
+	return &column{
+		name:    name,
+		element: elem,
+	}, nil
+}
+
+// scalarSchemaElement maps a Go scalar type (and any tag overrides) onto the
+// equivalent *parquet.SchemaElement. It mirrors the type table documented on
+// NewSchemaDefinitionFromStruct. Defaults are derived from t first, then any
+// type/convertedtype/logicaltype/length/utc tag keys are applied on top,
+// overriding the derived defaults.
+func scalarSchemaElement(name string, t reflect.Type, tag structTag) (*parquet.SchemaElement, error) {
+	elem := &parquet.SchemaElement{Name: name}
+
+	switch {
+	case t == timeTimeType:
+		elem.Type = typePtr(parquet.Type_INT64)
+		unit, utc := timestampUnit(tag, "MICROS")
+		elem.LogicalType = &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: utc, Unit: unit}}
+
+	case t == bigRatType:
+		if tag.Precision == 0 {
+			return nil, fmt.Errorf("big.Rat field %q requires a parquet tag with precision (and optionally scale)", name)
+		}
+		elem.Type = typePtr(decimalPhysicalType(tag.Precision))
+		if elem.GetType() == parquet.Type_FIXED_LEN_BYTE_ARRAY {
+			l := int32(decimalLength(tag.Precision))
+			elem.TypeLength = &l
+		}
+		ct := parquet.ConvertedType_DECIMAL
+		elem.ConvertedType = &ct
+		precision, scale := int32(tag.Precision), int32(tag.Scale)
+		elem.Precision = &precision
+		elem.Scale = &scale
+		elem.LogicalType = &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: precision, Scale: scale}}
+
+	default:
+		switch t.Kind() {
+		case reflect.Bool:
+			elem.Type = typePtr(parquet.Type_BOOLEAN)
+		case reflect.Int8:
+			elem.Type = typePtr(parquet.Type_INT32)
+			setIntLogical(elem, 8, true)
+		case reflect.Int16:
+			elem.Type = typePtr(parquet.Type_INT32)
+			setIntLogical(elem, 16, true)
+		case reflect.Int32:
+			elem.Type = typePtr(parquet.Type_INT32)
+		case reflect.Int, reflect.Int64:
+			elem.Type = typePtr(parquet.Type_INT64)
+		case reflect.Uint8:
+			elem.Type = typePtr(parquet.Type_INT32)
+			setIntLogical(elem, 8, false)
+		case reflect.Uint16:
+			elem.Type = typePtr(parquet.Type_INT32)
+			setIntLogical(elem, 16, false)
+		case reflect.Uint32:
+			elem.Type = typePtr(parquet.Type_INT32)
+			setIntLogical(elem, 32, false)
+		case reflect.Uint, reflect.Uint64:
+			elem.Type = typePtr(parquet.Type_INT64)
+			setIntLogical(elem, 64, false)
+		case reflect.Float32:
+			elem.Type = typePtr(parquet.Type_FLOAT)
+		case reflect.Float64:
+			elem.Type = typePtr(parquet.Type_DOUBLE)
+		case reflect.String:
+			elem.Type = typePtr(parquet.Type_BYTE_ARRAY)
+			ct := parquet.ConvertedType_UTF8
+			elem.ConvertedType = &ct
+			elem.LogicalType = &parquet.LogicalType{STRING: &parquet.StringType{}}
+		case reflect.Slice:
+			if t.Elem().Kind() != reflect.Uint8 {
+				return nil, fmt.Errorf("unsupported slice element type %s for field %q", t.Elem(), name)
+			}
+			elem.Type = typePtr(parquet.Type_BYTE_ARRAY)
+		case reflect.Array:
+			if t.Elem().Kind() != reflect.Uint8 {
+				return nil, fmt.Errorf("unsupported array element type %s for field %q", t.Elem(), name)
+			}
+			elem.Type = typePtr(parquet.Type_FIXED_LEN_BYTE_ARRAY)
+			l := int32(t.Len())
+			elem.TypeLength = &l
+		default:
+			return nil, fmt.Errorf("unsupported type %s for field %q", t, name)
+		}
+	}
+
+	if err := applyTagOverrides(elem, tag); err != nil {
+		return nil, fmt.Errorf("field %q: %w", name, err)
+	}
+
+	return elem, nil
+}
+
+// timestampUnit picks the TimeUnit and IsAdjustedToUTC for a time.Time field,
+// honoring a convertedtype/logicaltype tag of the form "TIMESTAMP_MILLIS",
+// "TIMESTAMP_MICROS" or "TIMESTAMP_NANOS" and a "utc" tag override. fallback
+// is the unit token used when the tag specifies none.
+func timestampUnit(tag structTag, fallback string) (*parquet.TimeUnit, bool) {
+	token := tag.LogicalType
+	if token == "" {
+		token = tag.ConvertedType
+	}
+	token = strings.TrimPrefix(token, "TIMESTAMP_")
+	if token == "" {
+		token = fallback
+	}
+
+	utc := true
+	if tag.UTC != nil {
+		utc = *tag.UTC
+	}
+
+	switch token {
+	case "MILLIS":
+		return &parquet.TimeUnit{MILLIS: &parquet.MilliSeconds{}}, utc
+	case "NANOS":
+		return &parquet.TimeUnit{NANOS: &parquet.NanoSeconds{}}, utc
+	default:
+		return &parquet.TimeUnit{MICROS: &parquet.MicroSeconds{}}, utc
+	}
+}
+
+// applyTagOverrides overrides elem's derived defaults with any type, length,
+// convertedtype, or logicaltype tag keys present on tag.
+func applyTagOverrides(elem *parquet.SchemaElement, tag structTag) error {
+	if tag.Type != "" {
+		pt, err := physicalTypeByName(tag.Type)
+		if err != nil {
+			return err
+		}
+		elem.Type = &pt
+	}
+	if tag.Length != 0 {
+		l := int32(tag.Length)
+		elem.TypeLength = &l
+	}
+	if tag.Precision != 0 {
+		p := int32(tag.Precision)
+		elem.Precision = &p
+	}
+	if tag.Scale != 0 {
+		s := int32(tag.Scale)
+		elem.Scale = &s
+	}
+
+	if tag.ConvertedType != "" {
+		ct, ok := convertedTypeByName(tag.ConvertedType)
+		if !ok {
+			return fmt.Errorf("unknown convertedtype %q", tag.ConvertedType)
+		}
+		elem.ConvertedType = &ct
+		elem.LogicalType = convertedToLogicalType(ct, elem)
+		if lt := elem.LogicalType; tag.UTC != nil && lt != nil {
+			switch {
+			case lt.IsSetTIMESTAMP():
+				lt.TIMESTAMP.IsAdjustedToUTC = *tag.UTC
+			case lt.IsSetTIME():
+				lt.TIME.IsAdjustedToUTC = *tag.UTC
+			}
+		}
+	}
+	if tag.LogicalType != "" {
+		lt, ok := logicalTypeByName(tag.LogicalType, elem, tag)
+		if !ok {
+			return fmt.Errorf("unknown logicaltype %q", tag.LogicalType)
+		}
+		elem.LogicalType = lt
+		if ct, ok := logicalToConvertedType(lt); ok {
+			elem.ConvertedType = &ct
+		}
+	}
+
+	return nil
+}
+
+// physicalTypeByName maps the `type=...` tag value onto a parquet.Type.
+func physicalTypeByName(name string) (parquet.Type, error) {
+	switch name {
+	case "BOOLEAN":
+		return parquet.Type_BOOLEAN, nil
+	case "INT32":
+		return parquet.Type_INT32, nil
+	case "INT64":
+		return parquet.Type_INT64, nil
+	case "INT96":
+		return parquet.Type_INT96, nil
+	case "FLOAT":
+		return parquet.Type_FLOAT, nil
+	case "DOUBLE":
+		return parquet.Type_DOUBLE, nil
+	case "BYTE_ARRAY", "BINARY":
+		return parquet.Type_BYTE_ARRAY, nil
+	case "FIXED_LEN_BYTE_ARRAY":
+		return parquet.Type_FIXED_LEN_BYTE_ARRAY, nil
+	default:
+		return 0, fmt.Errorf("unknown type %q", name)
+	}
+}
+
+// convertedTypeByName maps the `convertedtype=...` tag value onto a
+// parquet.ConvertedType, using the same token spelling as ParseSchemaDefinition
+// and printCols.
+func convertedTypeByName(name string) (parquet.ConvertedType, bool) {
+	switch name {
+	case "UTF8":
+		return parquet.ConvertedType_UTF8, true
+	case "MAP":
+		return parquet.ConvertedType_MAP, true
+	case "MAP_KEY_VALUE":
+		return parquet.ConvertedType_MAP_KEY_VALUE, true
+	case "LIST":
+		return parquet.ConvertedType_LIST, true
+	case "ENUM":
+		return parquet.ConvertedType_ENUM, true
+	case "DECIMAL":
+		return parquet.ConvertedType_DECIMAL, true
+	case "DATE":
+		return parquet.ConvertedType_DATE, true
+	case "TIME_MILLIS":
+		return parquet.ConvertedType_TIME_MILLIS, true
+	case "TIME_MICROS":
+		return parquet.ConvertedType_TIME_MICROS, true
+	case "TIMESTAMP_MILLIS":
+		return parquet.ConvertedType_TIMESTAMP_MILLIS, true
+	case "TIMESTAMP_MICROS":
+		return parquet.ConvertedType_TIMESTAMP_MICROS, true
+	case "UINT_8":
+		return parquet.ConvertedType_UINT_8, true
+	case "UINT_16":
+		return parquet.ConvertedType_UINT_16, true
+	case "UINT_32":
+		return parquet.ConvertedType_UINT_32, true
+	case "UINT_64":
+		return parquet.ConvertedType_UINT_64, true
+	case "INT_8":
+		return parquet.ConvertedType_INT_8, true
+	case "INT_16":
+		return parquet.ConvertedType_INT_16, true
+	case "INT_32":
+		return parquet.ConvertedType_INT_32, true
+	case "INT_64":
+		return parquet.ConvertedType_INT_64, true
+	case "JSON":
+		return parquet.ConvertedType_JSON, true
+	case "BSON":
+		return parquet.ConvertedType_BSON, true
+	case "INTERVAL":
+		return parquet.ConvertedType_INTERVAL, true
+	default:
+		return 0, false
+	}
+}
+
+// logicalTypeByName maps the `logicaltype=...` tag value onto a
+// *parquet.LogicalType. elem provides the Precision/Scale needed for DECIMAL;
+// tag provides the utc override needed for TIMESTAMP_*/TIME_*.
+func logicalTypeByName(name string, elem *parquet.SchemaElement, tag structTag) (*parquet.LogicalType, bool) {
+	utc := true
+	if tag.UTC != nil {
+		utc = *tag.UTC
+	}
+
+	switch name {
+	case "STRING", "UTF8":
+		return &parquet.LogicalType{STRING: &parquet.StringType{}}, true
+	case "DATE":
+		return &parquet.LogicalType{DATE: &parquet.DateType{}}, true
+	case "UUID":
+		return &parquet.LogicalType{UUID: &parquet.UUIDType{}}, true
+	case "ENUM":
+		return &parquet.LogicalType{ENUM: &parquet.EnumType{}}, true
+	case "JSON":
+		return &parquet.LogicalType{JSON: &parquet.JsonType{}}, true
+	case "BSON":
+		return &parquet.LogicalType{BSON: &parquet.BsonType{}}, true
+	case "MAP":
+		return &parquet.LogicalType{MAP: &parquet.MapType{}}, true
+	case "LIST":
+		return &parquet.LogicalType{LIST: &parquet.ListType{}}, true
+	case "DECIMAL":
+		return &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: elem.GetPrecision(), Scale: elem.GetScale()}}, true
+	case "INT_8":
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 8, IsSigned: true}}, true
+	case "INT_16":
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 16, IsSigned: true}}, true
+	case "INT_32":
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 32, IsSigned: true}}, true
+	case "INT_64":
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 64, IsSigned: true}}, true
+	case "UINT_8":
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 8, IsSigned: false}}, true
+	case "UINT_16":
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 16, IsSigned: false}}, true
+	case "UINT_32":
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 32, IsSigned: false}}, true
+	case "UINT_64":
+		return &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: 64, IsSigned: false}}, true
+	case "TIMESTAMP_MILLIS":
+		return &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: utc, Unit: &parquet.TimeUnit{MILLIS: &parquet.MilliSeconds{}}}}, true
+	case "TIMESTAMP_MICROS":
+		return &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: utc, Unit: &parquet.TimeUnit{MICROS: &parquet.MicroSeconds{}}}}, true
+	case "TIMESTAMP_NANOS":
+		return &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: utc, Unit: &parquet.TimeUnit{NANOS: &parquet.NanoSeconds{}}}}, true
+	case "TIME_MILLIS":
+		return &parquet.LogicalType{TIME: &parquet.TimeType{IsAdjustedToUTC: utc, Unit: &parquet.TimeUnit{MILLIS: &parquet.MilliSeconds{}}}}, true
+	case "TIME_MICROS":
+		return &parquet.LogicalType{TIME: &parquet.TimeType{IsAdjustedToUTC: utc, Unit: &parquet.TimeUnit{MICROS: &parquet.MicroSeconds{}}}}, true
+	case "TIME_NANOS":
+		return &parquet.LogicalType{TIME: &parquet.TimeType{IsAdjustedToUTC: utc, Unit: &parquet.TimeUnit{NANOS: &parquet.NanoSeconds{}}}}, true
+	default:
+		return nil, false
+	}
+}
+
+func setIntLogical(elem *parquet.SchemaElement, bitWidth int8, signed bool) {
+	var ct parquet.ConvertedType
+	switch {
+	case signed && bitWidth == 8:
+		ct = parquet.ConvertedType_INT_8
+	case signed && bitWidth == 16:
+		ct = parquet.ConvertedType_INT_16
+	case signed && bitWidth == 32:
+		ct = parquet.ConvertedType_INT_32
+	case signed && bitWidth == 64:
+		ct = parquet.ConvertedType_INT_64
+	case !signed && bitWidth == 8:
+		ct = parquet.ConvertedType_UINT_8
+	case !signed && bitWidth == 16:
+		ct = parquet.ConvertedType_UINT_16
+	case !signed && bitWidth == 32:
+		ct = parquet.ConvertedType_UINT_32
+	case !signed && bitWidth == 64:
+		ct = parquet.ConvertedType_UINT_64
+	}
+	elem.ConvertedType = &ct
+	elem.LogicalType = &parquet.LogicalType{INTEGER: &parquet.IntType{BitWidth: bitWidth, IsSigned: signed}}
+}
+
+func typePtr(t parquet.Type) *parquet.Type {
+	return &t
+}
+
+// decimalPhysicalType picks the physical encoding commonly used for a given
+// decimal precision: INT32 up to 9 digits, INT64 up to 18 digits, and
+// FIXED_LEN_BYTE_ARRAY beyond that.
+func decimalPhysicalType(precision int) parquet.Type {
+	switch {
+	case precision <= 9:
+		return parquet.Type_INT32
+	case precision <= 18:
+		return parquet.Type_INT64
+	default:
+		return parquet.Type_FIXED_LEN_BYTE_ARRAY
+	}
+}
+
+// decimalLength returns the minimum number of bytes needed to store a decimal
+// of the given precision as a two's-complement fixed length byte array.
+func decimalLength(precision int) int {
+	n := 1
+	for {
+		// max value representable in n bytes, two's complement: 2^(8n-1) - 1.
+		// The -1 accounts for the sign bit that isn't available for digits.
+		maxDigits := int(float64(n*8-1) * 0.3010299957) // log10(2) ~= 0.30103
+		if maxDigits >= precision {
+			return n
+		}
+		n++
+	}
+}
@@ -0,0 +1,191 @@
+package goparquet
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/fraugster/parquet-go/parquet"
+)
+
+func TestNewSchemaDefinitionFromStruct_Primitives(t *testing.T) {
+	type Primitives struct {
+		B    bool
+		I8   int8
+		I16  int16
+		I32  int32
+		I64  int64
+		U8   uint8
+		U32  uint32
+		F32  float32
+		F64  float64
+		Name string
+	}
+
+	sd, err := NewSchemaDefinitionFromStruct(Primitives{})
+	if err != nil {
+		t.Fatalf("NewSchemaDefinitionFromStruct: %v", err)
+	}
+
+	cases := []struct {
+		field    string
+		typ      parquet.Type
+		ct       parquet.ConvertedType
+		hasCT    bool
+		bitWidth int8
+		signed   bool
+	}{
+		{"I8", parquet.Type_INT32, parquet.ConvertedType_INT_8, true, 8, true},
+		{"I16", parquet.Type_INT32, parquet.ConvertedType_INT_16, true, 16, true},
+		{"U8", parquet.Type_INT32, parquet.ConvertedType_UINT_8, true, 8, false},
+		{"U32", parquet.Type_INT32, parquet.ConvertedType_UINT_32, true, 32, false},
+	}
+	for _, c := range cases {
+		elem := sd.SubSchema(c.field).SchemaElement()
+		if elem.GetType() != c.typ {
+			t.Errorf("%s: type = %s, want %s", c.field, elem.GetType(), c.typ)
+		}
+		if !elem.GetLogicalType().IsSetINTEGER() {
+			t.Fatalf("%s: expected INTEGER logical type", c.field)
+		}
+		if elem.LogicalType.INTEGER.BitWidth != c.bitWidth || elem.LogicalType.INTEGER.IsSigned != c.signed {
+			t.Errorf("%s: INTEGER = %+v, want bitWidth=%d signed=%t", c.field, elem.LogicalType.INTEGER, c.bitWidth, c.signed)
+		}
+		if elem.GetConvertedType() != c.ct {
+			t.Errorf("%s: ConvertedType = %s, want %s", c.field, elem.GetConvertedType(), c.ct)
+		}
+	}
+
+	if elem := sd.SubSchema("Name").SchemaElement(); !elem.GetLogicalType().IsSetSTRING() {
+		t.Errorf("Name: expected STRING logical type, got %+v", elem.LogicalType)
+	}
+}
+
+func TestNewSchemaDefinitionFromStruct_TagOverrides(t *testing.T) {
+	type Overridden struct {
+		AsInt64  int8   `parquet:"type=INT64"`
+		Fixed    []byte `parquet:"type=FIXED_LEN_BYTE_ARRAY,length=16"`
+		AsUTF8   []byte `parquet:"convertedtype=UTF8"`
+		AsSigned uint32 `parquet:"logicaltype=INT_32"`
+	}
+
+	sd, err := NewSchemaDefinitionFromStruct(Overridden{})
+	if err != nil {
+		t.Fatalf("NewSchemaDefinitionFromStruct: %v", err)
+	}
+
+	if elem := sd.SubSchema("AsInt64").SchemaElement(); elem.GetType() != parquet.Type_INT64 {
+		t.Errorf("AsInt64: type = %s, want INT64 (tag override ignored)", elem.GetType())
+	}
+
+	fixed := sd.SubSchema("Fixed").SchemaElement()
+	if fixed.GetType() != parquet.Type_FIXED_LEN_BYTE_ARRAY {
+		t.Errorf("Fixed: type = %s, want FIXED_LEN_BYTE_ARRAY", fixed.GetType())
+	}
+	if fixed.GetTypeLength() != 16 {
+		t.Errorf("Fixed: length = %d, want 16 (tag override ignored)", fixed.GetTypeLength())
+	}
+
+	utf8 := sd.SubSchema("AsUTF8").SchemaElement()
+	if utf8.GetConvertedType() != parquet.ConvertedType_UTF8 {
+		t.Errorf("AsUTF8: ConvertedType = %s, want UTF8 (tag override ignored)", utf8.GetConvertedType())
+	}
+	if !utf8.GetLogicalType().IsSetSTRING() {
+		t.Errorf("AsUTF8: expected synthesized STRING logical type, got %+v", utf8.LogicalType)
+	}
+
+	signed := sd.SubSchema("AsSigned").SchemaElement()
+	if !signed.GetLogicalType().IsSetINTEGER() || !signed.LogicalType.INTEGER.IsSigned {
+		t.Errorf("AsSigned: expected signed INTEGER logical type (tag override ignored), got %+v", signed.LogicalType)
+	}
+	if signed.GetConvertedType() != parquet.ConvertedType_INT_32 {
+		t.Errorf("AsSigned: ConvertedType = %s, want INT_32", signed.GetConvertedType())
+	}
+}
+
+func TestNewSchemaDefinitionFromStruct_TimeUnitAndUTC(t *testing.T) {
+	type Times struct {
+		Default time.Time
+		Millis  time.Time `parquet:"logicaltype=TIMESTAMP_MILLIS"`
+		Local   time.Time `parquet:"utc=false"`
+	}
+
+	sd, err := NewSchemaDefinitionFromStruct(Times{})
+	if err != nil {
+		t.Fatalf("NewSchemaDefinitionFromStruct: %v", err)
+	}
+
+	def := sd.SubSchema("Default").SchemaElement().GetLogicalType()
+	if !def.IsSetTIMESTAMP() || !def.TIMESTAMP.Unit.IsSetMICROS() || !def.TIMESTAMP.IsAdjustedToUTC {
+		t.Errorf("Default: want MICROS/UTC timestamp, got %+v", def.TIMESTAMP)
+	}
+
+	millis := sd.SubSchema("Millis").SchemaElement().GetLogicalType()
+	if !millis.TIMESTAMP.Unit.IsSetMILLIS() {
+		t.Errorf("Millis: want MILLIS unit (tag override ignored), got %+v", millis.TIMESTAMP.Unit)
+	}
+
+	local := sd.SubSchema("Local").SchemaElement().GetLogicalType()
+	if local.TIMESTAMP.IsAdjustedToUTC {
+		t.Errorf("Local: want IsAdjustedToUTC=false (utc tag override ignored)")
+	}
+}
+
+func TestNewSchemaDefinitionFromStruct_BigRat(t *testing.T) {
+	type Decimal struct {
+		Amount big.Rat `parquet:"precision=10,scale=2"`
+	}
+
+	sd, err := NewSchemaDefinitionFromStruct(Decimal{})
+	if err != nil {
+		t.Fatalf("NewSchemaDefinitionFromStruct: %v", err)
+	}
+
+	elem := sd.SubSchema("Amount").SchemaElement()
+	if elem.GetType() != parquet.Type_INT64 {
+		t.Errorf("Amount: type = %s, want INT64 (precision 10 fits in INT64)", elem.GetType())
+	}
+	if elem.GetPrecision() != 10 || elem.GetScale() != 2 {
+		t.Errorf("Amount: precision/scale = %d/%d, want 10/2", elem.GetPrecision(), elem.GetScale())
+	}
+
+	if _, err := NewSchemaDefinitionFromStruct(struct{ R big.Rat }{}); err == nil {
+		t.Error("expected error for big.Rat field without a precision tag")
+	}
+}
+
+func TestNewSchemaDefinitionFromStruct_BigRat_FixedLenByteArray(t *testing.T) {
+	type Decimal struct {
+		Amount big.Rat `parquet:"precision=19,scale=2"`
+	}
+
+	sd, err := NewSchemaDefinitionFromStruct(Decimal{})
+	if err != nil {
+		t.Fatalf("NewSchemaDefinitionFromStruct: %v", err)
+	}
+
+	elem := sd.SubSchema("Amount").SchemaElement()
+	if elem.GetType() != parquet.Type_FIXED_LEN_BYTE_ARRAY {
+		t.Fatalf("Amount: type = %s, want FIXED_LEN_BYTE_ARRAY (precision 19 overflows INT64)", elem.GetType())
+	}
+	if elem.GetTypeLength() != 9 {
+		t.Errorf("Amount: TypeLength = %d, want 9 (8-byte signed max 9.22e18 < 9.999e18)", elem.GetTypeLength())
+	}
+}
+
+func TestDecimalLength(t *testing.T) {
+	cases := []struct {
+		precision int
+		want      int
+	}{
+		{18, 8},
+		{19, 9},
+		{20, 9},
+		{38, 16},
+	}
+	for _, c := range cases {
+		if got := decimalLength(c.precision); got != c.want {
+			t.Errorf("decimalLength(%d) = %d, want %d", c.precision, got, c.want)
+		}
+	}
+}